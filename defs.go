@@ -0,0 +1,171 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// genContext はスキーマ生成の1回の呼び出しを通じて共有される状態を保持する。
+// 構造体型の出現回数と循環参照の有無を事前に解析し、"$defs"に切り出すべき型を
+// 判定したうえでスキーマを組み立てる。
+type genContext struct {
+	opts Options
+
+	counts map[reflect.Type]int  // 構造体型ごとの出現回数
+	cyclic map[reflect.Type]bool // 再帰パス上で再度出現した（循環を閉じる）構造体型
+
+	defs      map[string]map[string]any // 定義名 -> スキーマ（"$defs"の中身）
+	typeName  map[reflect.Type]string   // 型 -> 定義名
+	usedNames map[string]bool           // 定義名の衝突検出用
+}
+
+func newGenContext(opts Options) *genContext {
+	return &genContext{
+		opts:      opts,
+		counts:    map[reflect.Type]int{},
+		cyclic:    map[reflect.Type]bool{},
+		defs:      map[string]map[string]any{},
+		typeName:  map[reflect.Type]string{},
+		usedNames: map[string]bool{},
+	}
+}
+
+// analyze はtを起点に到達可能な構造体型を辿り、出現回数と循環参照を記録する。
+// ancestorsは現在の再帰パス上にある構造体型の集合で、同じ型が再度現れたら
+// 循環参照として扱う。
+func (c *genContext) analyze(t reflect.Type, ancestors map[reflect.Type]bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		c.counts[t]++
+
+		if ancestors[t] {
+			c.cyclic[t] = true
+			return
+		}
+
+		// 2回目以降の訪問ではフィールド構成は変わらないため再解析は不要。
+		if c.counts[t] > 1 {
+			return
+		}
+
+		ancestors[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, _, skip := parseJSONTag(field.Tag.Get("json")); skip {
+				continue
+			}
+			c.analyze(field.Type, ancestors)
+		}
+		delete(ancestors, t)
+
+	case reflect.Slice, reflect.Array:
+		c.analyze(t.Elem(), ancestors)
+
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			c.analyze(t.Elem(), ancestors)
+		}
+
+	case reflect.Interface:
+		// RegisterUnionで登録されたバリアントもinterfaceフィールド経由で
+		// 到達可能なので、出現回数・循環参照の判定に含める必要がある。
+		// そうしないと自己参照するバリアントがneedsDefでfalseと判定され、
+		// generateVariantRefが無限再帰してスタックオーバーフローする。
+		unionRegistryMu.RLock()
+		variants, ok := unionRegistry[t]
+		unionRegistryMu.RUnlock()
+		if ok {
+			for _, v := range variants {
+				c.analyze(v.typ, ancestors)
+			}
+		}
+	}
+}
+
+// needsDef はtを"$defs"に切り出して"$ref"で参照すべきかどうかを判定する。
+func (c *genContext) needsDef(t reflect.Type) bool {
+	return c.cyclic[t] || (c.opts.UseDefs && c.counts[t] > 1)
+}
+
+// generateStructRef はneedsDef(t)の判定に従って、構造体スキーマをインライン展開
+// するか、"$defs"に登録して"$ref"を返すかを決める。
+func (c *genContext) generateStructRef(t reflect.Type) map[string]any {
+	if !c.needsDef(t) {
+		return c.generateStructSchema(t)
+	}
+
+	name := c.nameFor(t)
+	ref := map[string]any{PropRef: "#/" + PropDefs + "/" + name}
+
+	if _, ok := c.defs[name]; ok {
+		return ref
+	}
+
+	// 循環参照の場合、generateStructSchema内で同じ型に再度到達して
+	// generateStructRefが呼ばれ得る。先にプレースホルダーを登録しておくことで
+	// その再入を"$ref"で打ち切り、無限再帰を防ぐ。
+	c.defs[name] = map[string]any{}
+	c.defs[name] = c.generateStructSchema(t)
+
+	return ref
+}
+
+// nameFor はtに対する"$defs"内の安定した名前を割り当てる。
+// 同名の型が既に存在する場合はパッケージ名で修飾し、それでも衝突する場合は
+// 連番を付与する。
+func (c *genContext) nameFor(t reflect.Type) string {
+	if name, ok := c.typeName[t]; ok {
+		return name
+	}
+
+	base := t.Name()
+	if base == "" {
+		base = "Anonymous"
+	}
+
+	name := base
+	if c.usedNames[name] {
+		name = qualifiedTypeName(t)
+	}
+	for i := 2; c.usedNames[name]; i++ {
+		name = fmt.Sprintf("%s_%d", qualifiedTypeName(t), i)
+	}
+
+	c.usedNames[name] = true
+	c.typeName[t] = name
+	return name
+}
+
+// qualifiedTypeName はtのパッケージ名で修飾した型名を返す。
+// 無名構造体はNameもPkgPathも空文字列になるため、衝突時に"$defs"キーが
+// 空文字列にならないよう"Anonymous"にフォールバックする。
+func qualifiedTypeName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		name = "Anonymous"
+	}
+
+	pkg := t.PkgPath()
+	if pkg == "" {
+		return name
+	}
+	parts := strings.Split(pkg, "/")
+	return parts[len(parts)-1] + "." + name
+}
+
+// defsAsAny はc.defsを"$defs"プロパティの値としてそのまま使える型に変換する。
+func (c *genContext) defsAsAny() map[string]any {
+	defs := make(map[string]any, len(c.defs))
+	for name, schema := range c.defs {
+		defs[name] = schema
+	}
+	return defs
+}
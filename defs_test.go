@@ -0,0 +1,201 @@
+package jsonschema
+
+import "testing"
+
+// 直接再帰する型（自己参照）は常に$defs/$refで処理されることを確認する。
+func TestGenerateDirectRecursion(t *testing.T) {
+	type Node struct {
+		Name     string
+		Children []*Node
+	}
+
+	schema, err := Generate(Node{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	defs, ok := schema[PropDefs].(map[string]any)
+	if !ok {
+		t.Fatalf("schema[%q] = %v, want a map", PropDefs, schema[PropDefs])
+	}
+	if _, ok := defs["Node"]; !ok {
+		t.Fatalf("defs = %v, want an entry for Node", defs)
+	}
+	if schema[PropSchema] != SchemaDraft {
+		t.Errorf("schema[%q] = %v, want %q", PropSchema, schema[PropSchema], SchemaDraft)
+	}
+	if schema[PropRef] != "#/$defs/Node" {
+		t.Errorf("schema[%q] = %v, want #/$defs/Node", PropRef, schema[PropRef])
+	}
+
+	nodeDef, ok := defs["Node"].(map[string]any)
+	if !ok {
+		t.Fatalf("defs[%q] = %v, want a map", "Node", defs["Node"])
+	}
+	props, ok := nodeDef[PropProperties].(map[string]any)
+	if !ok {
+		t.Fatalf("Node def has no properties: %v", nodeDef)
+	}
+	children, ok := props["Children"].(map[string]any)
+	if !ok {
+		t.Fatalf("Node.Children schema missing: %v", props)
+	}
+	items, ok := children[PropItems].(map[string]any)
+	if !ok {
+		t.Fatalf("Children items schema missing: %v", children)
+	}
+	if items[PropRef] != "#/$defs/Node" {
+		t.Errorf("Children items = %v, want $ref to #/$defs/Node", items)
+	}
+}
+
+// mutualRecursionB/mutualRecursionAはTestGenerateMutualRecursion専用の型で、
+// 相互再帰する型はGoの言語仕様上ローカルな型宣言では前方参照できないため
+// パッケージスコープに定義している。
+type mutualRecursionB struct {
+	Parent *mutualRecursionA
+}
+
+type mutualRecursionA struct {
+	Name  string
+	Child *mutualRecursionB
+}
+
+// 相互再帰する型（A→B→A）も循環が検出され、循環を閉じる型（A）が$defsに切り出されることを確認する。
+func TestGenerateMutualRecursion(t *testing.T) {
+	schema, err := Generate(mutualRecursionA{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	defs, ok := schema[PropDefs].(map[string]any)
+	if !ok {
+		t.Fatalf("schema[%q] = %v, want a map", PropDefs, schema[PropDefs])
+	}
+	// 循環を閉じる型（mutualRecursionA）が$defsに切り出されていれば、
+	// もう一方（mutualRecursionB）はインライン展開のままでも無限再帰は起きない。
+	aDef, ok := defs["mutualRecursionA"].(map[string]any)
+	if !ok {
+		t.Fatalf("defs = %v, want an entry for mutualRecursionA", defs)
+	}
+
+	props := aDef[PropProperties].(map[string]any)
+	child := props["Child"].(map[string]any)
+	childProps := child[PropProperties].(map[string]any)
+	parent := childProps["Parent"].(map[string]any)
+	if parent[PropRef] != "#/$defs/mutualRecursionA" {
+		t.Errorf("Parent = %v, want $ref back to #/$defs/mutualRecursionA", parent)
+	}
+}
+
+// UseDefs:trueの場合、循環がなくても複数回出現する共有リーフ構造体は
+// $defsに切り出され$refで参照される。
+func TestGenerateWithOptionsSharedLeaf(t *testing.T) {
+	type Address struct {
+		Street string
+		City   string
+	}
+	type Company struct {
+		Name    string
+		Address Address
+	}
+	type Person struct {
+		Name        string
+		HomeAddress Address
+		WorkAddress Address
+		Employer    Company
+	}
+
+	schema, err := GenerateWithOptions(Person{}, Options{UseDefs: true})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	defs, ok := schema[PropDefs].(map[string]any)
+	if !ok {
+		t.Fatalf("schema[%q] = %v, want a map", PropDefs, schema[PropDefs])
+	}
+	if _, ok := defs["Address"]; !ok {
+		t.Fatalf("defs = %v, want an entry for Address (seen 3 times)", defs)
+	}
+
+	props := schema[PropProperties].(map[string]any)
+	home, ok := props["HomeAddress"].(map[string]any)
+	if !ok || home[PropRef] != "#/$defs/Address" {
+		t.Errorf("HomeAddress = %v, want $ref to #/$defs/Address", home)
+	}
+	work, ok := props["WorkAddress"].(map[string]any)
+	if !ok || work[PropRef] != "#/$defs/Address" {
+		t.Errorf("WorkAddress = %v, want $ref to #/$defs/Address", work)
+	}
+
+	// Companyはただ一度しか出現しないのでインライン展開されたままのはず。
+	employer, ok := props["Employer"].(map[string]any)
+	if !ok {
+		t.Fatalf("Employer schema missing: %v", props)
+	}
+	if _, isRef := employer[PropRef]; isRef {
+		t.Errorf("Employer = %v, want inline schema (seen once)", employer)
+	}
+}
+
+// 2つ以上の異なる無名構造体型がそれぞれ複数回出現しても、"$defs"のキーが
+// 空文字列に衝突しないことを確認する（無名構造体はNameもPkgPathも空文字列
+// になるため）。
+func TestGenerateWithOptionsDistinctAnonymousStructs(t *testing.T) {
+	type Wrapper struct {
+		A1 struct{ X int }
+		A2 struct{ X int }
+		B1 struct{ Y string }
+		B2 struct{ Y string }
+	}
+
+	schema, err := GenerateWithOptions(Wrapper{}, Options{UseDefs: true})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions() error = %v", err)
+	}
+
+	defs, ok := schema[PropDefs].(map[string]any)
+	if !ok {
+		t.Fatalf("schema[%q] = %v, want a map", PropDefs, schema[PropDefs])
+	}
+
+	if _, hasEmptyKey := defs[""]; hasEmptyKey {
+		t.Fatalf("defs = %v, want no empty-string key", defs)
+	}
+	if len(defs) != 2 {
+		t.Fatalf("defs = %v, want exactly 2 entries for the 2 distinct anonymous struct types", defs)
+	}
+
+	props := schema[PropProperties].(map[string]any)
+	for _, fieldName := range []string{"A1", "A2", "B1", "B2"} {
+		field, ok := props[fieldName].(map[string]any)
+		if !ok {
+			t.Fatalf("props[%q] missing", fieldName)
+		}
+		ref, ok := field[PropRef].(string)
+		if !ok || ref == "#/$defs/" {
+			t.Errorf("props[%q][%q] = %v, want a non-empty $ref", fieldName, PropRef, field[PropRef])
+		}
+	}
+}
+
+// UseDefsを指定しない場合は、循環がない限り従来通りインライン展開される
+// （後方互換性の確認）。
+func TestGenerateWithoutOptionsKeepsInlining(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	type Person struct {
+		Home Address
+		Work Address
+	}
+
+	schema, err := Generate(Person{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, ok := schema[PropDefs]; ok {
+		t.Errorf("schema has %q, want no defs without UseDefs", PropDefs)
+	}
+}
@@ -0,0 +1,208 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Schema はLLMのstructured output APIにそのまま渡せるJSON Schemaのラッパー。
+// Rawが生成されたスキーマそのものであり、MarshalJSONでリクエストボディに
+// 埋め込める。
+type Schema struct {
+	Raw map[string]any
+}
+
+// MarshalJSON はs.Rawをそのままエンコードする。
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Raw)
+}
+
+// Unmarshal はdataをsのスキーマに照らして検証したうえでdstにデコードする。
+func (s *Schema) Unmarshal(data []byte, dst any) error {
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("jsonschema: cannot unmarshal data: %w", err)
+	}
+
+	validator, err := Compile(s.Raw)
+	if err != nil {
+		return err
+	}
+	if err := validator.Validate(generic); err != nil {
+		return fmt.Errorf("jsonschema: response does not match schema: %w", err)
+	}
+
+	return json.Unmarshal(data, dst)
+}
+
+// StrictOptions はGenerateStrictWithOptionsの挙動を制御する。
+type StrictOptions struct {
+	// AllowExtendedKeywords をtrueにすると、OpenAIのstructured outputが
+	// 受け付けないキーワード（pattern, format, minimumなど）を削除せずに残す。
+	AllowExtendedKeywords bool
+}
+
+// strictStrippedKeywords はOpenAIのstrict modeが受け付けないキーワード。
+// AllowExtendedKeywordsが指定されない限りGenerateStrictで取り除かれる。
+var strictStrippedKeywords = []string{
+	PropPattern, PropFormat,
+	PropMinimum, PropMaximum, PropExclusiveMinimum, PropExclusiveMaximum, PropMultipleOf,
+	PropMinItems, PropMaxItems, PropMinLength, PropMaxLength, PropUniqueItems,
+}
+
+// GenerateStrict はvの型からOpenAIのstructured output strict modeに適合する
+// JSON Schemaを生成する。
+func GenerateStrict(v any) (*Schema, error) {
+	return GenerateStrictWithOptions(v, StrictOptions{})
+}
+
+// GenerateForStructuredOutput はTのゼロ値からstrict modeのSchemaを生成する
+// ジェネリックのショートカット。
+func GenerateForStructuredOutput[T any]() (*Schema, error) {
+	var zero T
+	return GenerateStrict(zero)
+}
+
+// GenerateStrictWithOptions はoptsに従ってvの型からstrict modeのSchemaを生成する。
+func GenerateStrictWithOptions(v any, opts StrictOptions) (*Schema, error) {
+	if v == nil {
+		return nil, fmt.Errorf("cannot generate schema from nil value")
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct type, got %s", t.Kind())
+	}
+
+	if err := checkStrictType(t, map[reflect.Type]bool{}); err != nil {
+		return nil, err
+	}
+
+	raw, err := GenerateWithOptions(v, Options{UseDefs: true})
+	if err != nil {
+		return nil, err
+	}
+
+	applyStrictMode(raw, opts)
+
+	return &Schema{Raw: raw}, nil
+}
+
+// checkStrictType はtの中に、strict modeが扱えない型（マップ全般）がないかを
+// 確認する。OpenAIのstrict modeはすべてのオブジェクトでプロパティを列挙し
+// additionalProperties:falseにすることを要求しており、任意キーを許す辞書型
+// （additionalPropertiesがスキーマになるもの）を表現できないため、
+// 文字列キーかどうかによらずマップ自体を拒否する。
+// RegisterUnionで登録されたバリアントもinterfaceフィールド経由で生成対象に
+// 含まれ得るため、interface型もそのバリアント全てを辿って確認する。
+func checkStrictType(t reflect.Type, visited map[reflect.Type]bool) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		return fmt.Errorf("jsonschema: strict mode does not support map/dictionary fields (found map[%s]%s); use a struct with enumerated fields instead", t.Key().Kind(), t.Elem())
+
+	case reflect.Slice, reflect.Array:
+		return checkStrictType(t.Elem(), visited)
+
+	case reflect.Interface:
+		unionRegistryMu.RLock()
+		variants, ok := unionRegistry[t]
+		unionRegistryMu.RUnlock()
+		if ok {
+			for _, v := range variants {
+				if err := checkStrictType(v.typ, visited); err != nil {
+					return err
+				}
+			}
+		}
+
+	case reflect.Struct:
+		if visited[t] {
+			return nil
+		}
+		visited[t] = true
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, _, skip := parseJSONTag(field.Tag.Get("json")); skip {
+				continue
+			}
+			if names, ok := parseOneOfTag(field.Tag.Get("jsonschema")); ok {
+				for _, name := range names {
+					namedTypesMu.RLock()
+					vt, ok := namedTypes[name]
+					namedTypesMu.RUnlock()
+					if ok {
+						if err := checkStrictType(vt, visited); err != nil {
+							return err
+						}
+					}
+				}
+				continue
+			}
+			if err := checkStrictType(field.Type, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyStrictMode はschemaの全オブジェクト階層に対し、
+// additionalProperties:falseの強制とrequiredへの全プロパティの追加を行い、
+// AllowExtendedKeywordsが指定されない限りOpenAIが受け付けないキーワードを
+// 取り除く。
+func applyStrictMode(schema map[string]any, opts StrictOptions) {
+	if schema == nil {
+		return
+	}
+
+	if !opts.AllowExtendedKeywords {
+		for _, key := range strictStrippedKeywords {
+			delete(schema, key)
+		}
+	}
+
+	if props, ok := schema[PropProperties].(map[string]any); ok {
+		schema[PropAdditionalProperties] = false
+
+		required := make([]string, 0, len(props))
+		for name := range props {
+			required = append(required, name)
+		}
+		sort.Strings(required)
+		schema[PropRequired] = required
+
+		for _, propSchema := range props {
+			if m, ok := propSchema.(map[string]any); ok {
+				applyStrictMode(m, opts)
+			}
+		}
+	} else if ap, ok := schema[PropAdditionalProperties].(map[string]any); ok {
+		applyStrictMode(ap, opts)
+	}
+
+	if items, ok := schema[PropItems].(map[string]any); ok {
+		applyStrictMode(items, opts)
+	}
+
+	if defs, ok := schema[PropDefs].(map[string]any); ok {
+		for _, def := range defs {
+			if m, ok := def.(map[string]any); ok {
+				applyStrictMode(m, opts)
+			}
+		}
+	}
+}
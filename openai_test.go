@@ -0,0 +1,147 @@
+package jsonschema
+
+import "testing"
+
+// strict modeでは、omitemptyやポインタ型であってもすべてのプロパティが
+// requiredに含まれ、additionalPropertiesはネストしたオブジェクトでもfalseに
+// なることを確認する。
+func TestGenerateStrict(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city,omitempty"`
+	}
+	type Person struct {
+		Name    string   `json:"name" validate:"pattern=^[A-Z]"`
+		Age     *int     `json:"age,omitempty"`
+		Address Address  `json:"address"`
+		Tags    []string `json:"tags" validate:"minItems=1"`
+	}
+
+	schema, err := GenerateStrict(Person{})
+	if err != nil {
+		t.Fatalf("GenerateStrict() error = %v", err)
+	}
+
+	if schema.Raw[PropAdditionalProperties] != false {
+		t.Errorf("root additionalProperties = %v, want false", schema.Raw[PropAdditionalProperties])
+	}
+
+	required, ok := schema.Raw[PropRequired].([]string)
+	if !ok || len(required) != 4 {
+		t.Fatalf("root required = %v, want all 4 properties", schema.Raw[PropRequired])
+	}
+
+	props := schema.Raw[PropProperties].(map[string]any)
+
+	name := props["name"].(map[string]any)
+	if _, hasPattern := name[PropPattern]; hasPattern {
+		t.Errorf("name schema still has pattern: %v", name)
+	}
+
+	address := props["address"].(map[string]any)
+	if address[PropAdditionalProperties] != false {
+		t.Errorf("nested additionalProperties = %v, want false", address[PropAdditionalProperties])
+	}
+	nestedRequired, ok := address[PropRequired].([]string)
+	if !ok || len(nestedRequired) != 2 {
+		t.Errorf("nested required = %v, want both street and city", address[PropRequired])
+	}
+
+	tags := props["tags"].(map[string]any)
+	if _, hasMinItems := tags[PropMinItems]; hasMinItems {
+		t.Errorf("tags schema still has minItems: %v", tags)
+	}
+}
+
+// AllowExtendedKeywordsを指定すると、OpenAIが受け付けないキーワードも残る。
+func TestGenerateStrictAllowExtendedKeywords(t *testing.T) {
+	type Item struct {
+		Code string `json:"code" validate:"pattern=^[A-Z]+$"`
+	}
+
+	schema, err := GenerateStrictWithOptions(Item{}, StrictOptions{AllowExtendedKeywords: true})
+	if err != nil {
+		t.Fatalf("GenerateStrictWithOptions() error = %v", err)
+	}
+
+	props := schema.Raw[PropProperties].(map[string]any)
+	code := props["code"].(map[string]any)
+	if code[PropPattern] != "^[A-Z]+$" {
+		t.Errorf("code pattern = %v, want preserved pattern", code[PropPattern])
+	}
+}
+
+// 文字列以外をキーに持つマップはstrict modeでサポートされないため、明確な
+// エラーになることを確認する。
+func TestGenerateStrictRejectsNonStringMapKeys(t *testing.T) {
+	type BadStruct struct {
+		Scores map[int]string `json:"scores"`
+	}
+
+	if _, err := GenerateStrict(BadStruct{}); err == nil {
+		t.Error("GenerateStrict() error = nil, want an error for non-string map key")
+	}
+}
+
+// 文字列キーのマップであっても、additionalPropertiesがスキーマになって
+// しまいOpenAIのstrict modeでは使えないため、同様にエラーにする。
+func TestGenerateStrictRejectsStringKeyedMaps(t *testing.T) {
+	type BadStruct struct {
+		Meta map[string]string `json:"meta"`
+	}
+
+	if _, err := GenerateStrict(BadStruct{}); err == nil {
+		t.Error("GenerateStrict() error = nil, want an error for map fields (even string-keyed)")
+	}
+}
+
+type strictUnionVariantWithMap struct {
+	Meta map[string]string `json:"meta"`
+}
+
+type strictUnionShape interface{ isStrictUnionShape() }
+
+func (strictUnionVariantWithMap) isStrictUnionShape() {}
+
+// RegisterUnionのバリアントがinterfaceフィールド経由でしか到達できない
+// 場合でも、checkStrictTypeがunionRegistryを辿ってマップフィールドを
+// 検出し、strict modeでエラーになることを確認する。
+func TestGenerateStrictRejectsMapsInsideUnionVariants(t *testing.T) {
+	RegisterUnion((*strictUnionShape)(nil), strictUnionVariantWithMap{})
+
+	type Drawing struct {
+		Shape strictUnionShape `json:"shape"`
+	}
+
+	if _, err := GenerateStrict(Drawing{}); err == nil {
+		t.Error("GenerateStrict() error = nil, want an error for a map hidden inside a union variant")
+	}
+}
+
+// GenerateForStructuredOutput[T]とSchema.Unmarshalを組み合わせた
+// 「スキーマ生成→モデル応答のパース」の一連の流れを確認する。
+func TestGenerateForStructuredOutputAndUnmarshal(t *testing.T) {
+	type Recipe struct {
+		Title       string   `json:"title"`
+		Ingredients []string `json:"ingredients"`
+	}
+
+	schema, err := GenerateForStructuredOutput[Recipe]()
+	if err != nil {
+		t.Fatalf("GenerateForStructuredOutput() error = %v", err)
+	}
+
+	raw := []byte(`{"title":"Omelette","ingredients":["egg","butter"]}`)
+	var recipe Recipe
+	if err := schema.Unmarshal(raw, &recipe); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if recipe.Title != "Omelette" || len(recipe.Ingredients) != 2 {
+		t.Errorf("recipe = %+v, want decoded fields", recipe)
+	}
+
+	invalid := []byte(`{"title":"Omelette"}`)
+	if err := schema.Unmarshal(invalid, &recipe); err == nil {
+		t.Error("Unmarshal() error = nil, want an error for missing required ingredients")
+	}
+}
@@ -5,8 +5,21 @@ import (
 	"reflect"
 )
 
+// Options はスキーマ生成時の挙動を制御する。
+type Options struct {
+	// UseDefs を true にすると、複数回出現する構造体型を"$defs"に切り出し、
+	// "$ref"で参照するようにする。循環参照を持つ型はUseDefsの値に関わらず
+	// 常に"$defs"/"$ref"で処理される（そうしないと生成が無限再帰に陥るため）。
+	UseDefs bool
+}
+
 // Generate はGoの構造体からJSON Schemaを生成する。
 func Generate(v any) (map[string]any, error) {
+	return GenerateWithOptions(v, Options{})
+}
+
+// GenerateWithOptions はoptsに従ってGoの構造体からJSON Schemaを生成する。
+func GenerateWithOptions(v any, opts Options) (map[string]any, error) {
 	if v == nil {
 		return nil, fmt.Errorf("cannot generate schema from nil value")
 	}
@@ -20,11 +33,21 @@ func Generate(v any) (map[string]any, error) {
 		return nil, fmt.Errorf("expected struct type, got %s", t.Kind())
 	}
 
-	return generateSchema(t), nil
+	ctx := newGenContext(opts)
+	ctx.analyze(t, map[reflect.Type]bool{})
+
+	schema := ctx.generateSchema(t)
+
+	if len(ctx.defs) > 0 {
+		schema[PropSchema] = SchemaDraft
+		schema[PropDefs] = ctx.defsAsAny()
+	}
+
+	return schema, nil
 }
 
 // generateSchema はreflect.TypeからJSON Schemaを再帰的に生成する。
-func generateSchema(t reflect.Type) map[string]any {
+func (c *genContext) generateSchema(t reflect.Type) map[string]any {
 	// ポインタ型の処理
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
@@ -39,7 +62,7 @@ func generateSchema(t reflect.Type) map[string]any {
 	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
 		return map[string]any{
 			PropType:  TypeArray,
-			PropItems: generateSchema(t.Elem()),
+			PropItems: c.generateSchema(t.Elem()),
 		}
 	}
 
@@ -52,13 +75,18 @@ func generateSchema(t reflect.Type) map[string]any {
 		}
 		return map[string]any{
 			PropType:                 TypeObject,
-			PropAdditionalProperties: generateSchema(t.Elem()),
+			PropAdditionalProperties: c.generateSchema(t.Elem()),
 		}
 	}
 
-	// 構造体の処理
+	// 構造体の処理（$defs/$refが必要な型はここで切り出す）
 	if t.Kind() == reflect.Struct {
-		return generateStructSchema(t)
+		return c.generateStructRef(t)
+	}
+
+	// インターフェースの処理（RegisterUnionで登録されていればoneOfを生成する）
+	if t.Kind() == reflect.Interface {
+		return c.generateInterfaceSchema(t)
 	}
 
 	// 未知の型のフォールバック
@@ -68,7 +96,7 @@ func generateSchema(t reflect.Type) map[string]any {
 }
 
 // generateStructSchema は構造体型のJSON Schemaを生成する。
-func generateStructSchema(t reflect.Type) map[string]any {
+func (c *genContext) generateStructSchema(t reflect.Type) map[string]any {
 	schema := map[string]any{
 		PropType:                 TypeObject,
 		PropProperties:           map[string]any{},
@@ -97,15 +125,21 @@ func generateStructSchema(t reflect.Type) map[string]any {
 		}
 
 		// フィールドの型からスキーマを生成
-		fieldSchema := generateSchema(field.Type)
+		fieldSchema := c.generateSchema(field.Type)
 
 		// validateタグを解析
 		validationTag := field.Tag.Get("validate")
 		if validationTag != "" {
-			constraints := parseValidationTag(validationTag)
-			for key, value := range constraints {
-				fieldSchema[key] = value
+			mergeConstraints(fieldSchema, parseValidationTag(validationTag, field.Type))
+		}
+
+		// jsonschemaタグを解析（description/titleなどのドキュメント注釈用、
+		// および"oneOf=TypeA|TypeB"形式のタグ付きユニオン指定）
+		if jsonschemaTag := field.Tag.Get("jsonschema"); jsonschemaTag != "" {
+			if variantNames, ok := parseOneOfTag(jsonschemaTag); ok {
+				fieldSchema = c.generateTaggedUnionSchema(variantNames)
 			}
+			mergeConstraints(fieldSchema, parseJSONSchemaTag(jsonschemaTag, field.Type))
 		}
 
 		// requiredフィールドかどうかを判定
@@ -124,3 +158,16 @@ func generateStructSchema(t reflect.Type) map[string]any {
 
 	return schema
 }
+
+// mergeConstraints はconstraintsのキーをfieldSchemaに書き込む。examplesは
+// validate/jsonschema両方のタグで指定され得るため、上書きせず追記する。
+func mergeConstraints(fieldSchema, constraints map[string]any) {
+	for key, value := range constraints {
+		if key == PropExamples {
+			existing, _ := fieldSchema[PropExamples].([]any)
+			fieldSchema[PropExamples] = append(existing, value.([]any)...)
+			continue
+		}
+		fieldSchema[key] = value
+	}
+}
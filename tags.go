@@ -33,6 +33,7 @@ func parseJSONTag(tag string) (name string, omitempty bool, skip bool) {
 }
 
 // parseValidationTag はvalidateタグを解析し、JSON Schema制約のマップを返す。
+// fieldTypeはenum/constの値を正しい型（整数・数値・文字列）にするために使う。
 // サポートする制約:
 //   - required: required配列に追加（別途処理）
 //   - minimum=N: 数値の最小値を設定
@@ -44,7 +45,26 @@ func parseJSONTag(tag string) (name string, omitempty bool, skip bool) {
 //   - format=FORMAT: 文字列のフォーマットを設定（date-time, time, date, duration, email, hostname, ipv4, ipv6, uuid）
 //   - minItems=N: 配列の最小要素数を設定
 //   - maxItems=N: 配列の最大要素数を設定
-func parseValidationTag(tag string) map[string]any {
+//   - minLength=N: 文字列の最小長を設定
+//   - maxLength=N: 文字列の最大長を設定
+//   - enum=a|b|c: フィールドの型に応じて型付けされたenumを設定
+//   - const=value: フィールドの型に応じて型付けされたconstを設定
+//   - uniqueItems: 配列の要素が重複しないことを要求するフラグ
+//   - description=..., title=..., default=..., example=...（repeatable）: ドキュメント注釈
+func parseValidationTag(tag string, fieldType reflect.Type) map[string]any {
+	return parseConstraintTag(tag, fieldType)
+}
+
+// parseJSONSchemaTag はjsonschemaタグを解析する。parseValidationTagと同じ
+// キーをサポートし、主にdescription/title/default/exampleなどのドキュメント
+// 注釈をvalidateタグと分けて書きたい場合に使う。
+func parseJSONSchemaTag(tag string, fieldType reflect.Type) map[string]any {
+	return parseConstraintTag(tag, fieldType)
+}
+
+// parseConstraintTag はvalidate/jsonschemaタグに共通の解析ロジックで、
+// key=value形式の制約とuniqueItemsのようなフラグ形式の制約を読み取る。
+func parseConstraintTag(tag string, fieldType reflect.Type) map[string]any {
 	constraints := make(map[string]any)
 
 	if tag == "" {
@@ -111,7 +131,30 @@ func parseValidationTag(tag string) map[string]any {
 				if num, err := strconv.Atoi(value); err == nil {
 					constraints[PropMaxItems] = num
 				}
+			case "minLength":
+				if num, err := strconv.Atoi(value); err == nil {
+					constraints[PropMinLength] = num
+				}
+			case "maxLength":
+				if num, err := strconv.Atoi(value); err == nil {
+					constraints[PropMaxLength] = num
+				}
+			case "enum":
+				constraints[PropEnum] = parseEnumValues(value, fieldType)
+			case "const":
+				constraints[PropConst] = parseTypedScalar(value, fieldType)
+			case "description":
+				constraints[PropDescription] = value
+			case "title":
+				constraints[PropTitle] = value
+			case "default":
+				constraints[PropDefault] = parseTypedScalar(value, fieldType)
+			case "example":
+				examples, _ := constraints[PropExamples].([]any)
+				constraints[PropExamples] = append(examples, parseTypedScalar(value, fieldType))
 			}
+		} else if part == "uniqueItems" {
+			constraints[PropUniqueItems] = true
 		}
 		// NOTE: "required"はisRequiredFieldで別途処理
 	}
@@ -119,6 +162,70 @@ func parseValidationTag(tag string) map[string]any {
 	return constraints
 }
 
+// parseEnumValues は"a|b|c"形式のenum制約をフィールドの型に応じて型付けされた
+// スライスに変換する。
+func parseEnumValues(value string, fieldType reflect.Type) []any {
+	parts := strings.Split(value, "|")
+	enum := make([]any, 0, len(parts))
+	for _, p := range parts {
+		enum = append(enum, parseTypedScalar(strings.TrimSpace(p), fieldType))
+	}
+	return enum
+}
+
+// parseTypedScalar はタグの文字列値を、フィールドの型に応じてint64/uint64/float64/
+// bool/stringのいずれかに変換する。変換に失敗した場合は文字列のまま返す。
+func parseTypedScalar(value string, fieldType reflect.Type) any {
+	if fieldType == nil {
+		return value
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if num, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return num
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// int64では表現できないuint64の値（math.MaxInt64超）もあるため、
+		// 符号なし整数はParseUintで読み取る。
+		if num, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return num
+		}
+	case reflect.Float32, reflect.Float64:
+		if num, err := strconv.ParseFloat(value, 64); err == nil {
+			return num
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+
+	return value
+}
+
+// parseOneOfTag はjsonschemaタグから"oneOf=TypeA|TypeB"形式のタグ付き
+// ユニオン指定を取り出す。見つからなければ(nil, false)を返す。
+// 指定されたTypeA/TypeBはRegisterUnionで一度は登録されていて、名前から
+// reflect.Typeを引けるようになっている必要がある。
+func parseOneOfTag(tag string) ([]string, bool) {
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if !strings.HasPrefix(part, "oneOf=") {
+			continue
+		}
+
+		value := strings.TrimPrefix(part, "oneOf=")
+		names := strings.Split(value, "|")
+		for i, name := range names {
+			names[i] = strings.TrimSpace(name)
+		}
+		return names, true
+	}
+
+	return nil, false
+}
+
 // isRequiredField はフィールドがrequiredかどうかを判定する。
 // 判定ルール:
 //   - json:omitempty が指定されている場合は required 扱いしない
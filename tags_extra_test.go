@@ -0,0 +1,125 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+// enum/const/length/uniqueItems/ドキュメント注釈タグの生成結果を確認する。
+func TestGenerateExtendedTags(t *testing.T) {
+	type Product struct {
+		Status   string   `json:"status" validate:"enum=draft|published|archived"`
+		Kind     string   `json:"kind" validate:"const=product"`
+		Priority int      `json:"priority" validate:"enum=1|2|3"`
+		Name     string   `json:"name" validate:"minLength=1,maxLength=50" jsonschema:"description=製品名,title=Name,example=Widget,example=Gadget"`
+		Tags     []string `json:"tags" validate:"uniqueItems"`
+	}
+
+	schema, err := Generate(Product{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	props := schema[PropProperties].(map[string]any)
+
+	status := props["status"].(map[string]any)
+	if !reflect.DeepEqual(status[PropEnum], []any{"draft", "published", "archived"}) {
+		t.Errorf("status enum = %v", status[PropEnum])
+	}
+
+	kind := props["kind"].(map[string]any)
+	if kind[PropConst] != "product" {
+		t.Errorf("kind const = %v, want %q", kind[PropConst], "product")
+	}
+
+	priority := props["priority"].(map[string]any)
+	if !reflect.DeepEqual(priority[PropEnum], []any{int64(1), int64(2), int64(3)}) {
+		t.Errorf("priority enum = %v, want typed int64 values", priority[PropEnum])
+	}
+
+	name := props["name"].(map[string]any)
+	if name[PropMinLength] != 1 || name[PropMaxLength] != 50 {
+		t.Errorf("name length constraints = %v", name)
+	}
+	if name[PropDescription] != "製品名" || name[PropTitle] != "Name" {
+		t.Errorf("name annotations = %v", name)
+	}
+	if !reflect.DeepEqual(name[PropExamples], []any{"Widget", "Gadget"}) {
+		t.Errorf("name examples = %v", name[PropExamples])
+	}
+
+	tags := props["tags"].(map[string]any)
+	if tags[PropUniqueItems] != true {
+		t.Errorf("tags uniqueItems = %v, want true", tags[PropUniqueItems])
+	}
+}
+
+// math.MaxInt64を超えるuint64フィールドのenum/constが、strconv.ParseIntの
+// オーバーフローで文字列にフォールバックせず、数値として型付けされることを
+// 確認する。
+func TestParseTypedScalarLargeUint64(t *testing.T) {
+	type Flags struct {
+		Mask uint64 `json:"mask" validate:"enum=18446744073709551615,const=18446744073709551615"`
+	}
+
+	schema, err := Generate(Flags{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	props := schema[PropProperties].(map[string]any)
+	mask := props["mask"].(map[string]any)
+
+	if _, isString := mask[PropConst].(string); isString {
+		t.Fatalf("mask const = %v (%T), want a numeric uint64, not a string", mask[PropConst], mask[PropConst])
+	}
+	if mask[PropConst] != uint64(18446744073709551615) {
+		t.Errorf("mask const = %v, want 18446744073709551615", mask[PropConst])
+	}
+
+	enum, ok := mask[PropEnum].([]any)
+	if !ok || len(enum) != 1 || enum[0] != uint64(18446744073709551615) {
+		t.Errorf("mask enum = %v, want [18446744073709551615] as uint64", mask[PropEnum])
+	}
+}
+
+// Validatorがenum/const/minLength/maxLength/uniqueItemsを強制することを確認する。
+func TestValidatorExtendedKeywords(t *testing.T) {
+	type Product struct {
+		Status string   `json:"status" validate:"enum=draft|published"`
+		Kind   string   `json:"kind" validate:"const=product"`
+		Name   string   `json:"name" validate:"minLength=2,maxLength=5"`
+		Tags   []string `json:"tags" validate:"uniqueItems"`
+	}
+
+	schema, err := Generate(Product{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	valid := map[string]any{
+		"status": "draft",
+		"kind":   "product",
+		"name":   "Acme",
+		"tags":   []any{"a", "b"},
+	}
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	tests := []map[string]any{
+		{"status": "unknown", "kind": "product", "name": "Acme", "tags": []any{"a"}},
+		{"status": "draft", "kind": "other", "name": "Acme", "tags": []any{"a"}},
+		{"status": "draft", "kind": "product", "name": "A", "tags": []any{"a"}},
+		{"status": "draft", "kind": "product", "name": "Acme", "tags": []any{"a", "a"}},
+	}
+	for i, tt := range tests {
+		if err := validator.Validate(tt); err == nil {
+			t.Errorf("case %d: Validate() error = nil, want an error for %v", i, tt)
+		}
+	}
+}
@@ -28,8 +28,25 @@ const (
 	PropFormat               = "format"
 	PropMinItems             = "minItems"
 	PropMaxItems             = "maxItems"
+	PropSchema               = "$schema"
+	PropRef                  = "$ref"
+	PropDefs                 = "$defs"
+	PropEnum                 = "enum"
+	PropConst                = "const"
+	PropMinLength            = "minLength"
+	PropMaxLength            = "maxLength"
+	PropUniqueItems          = "uniqueItems"
+	PropDescription          = "description"
+	PropTitle                = "title"
+	PropDefault              = "default"
+	PropExamples             = "examples"
+	PropOneOf                = "oneOf"
+	PropDiscriminator        = "discriminator"
 )
 
+// SchemaDraft はGenerateが$defsを使用する際に"$schema"に設定するJSON Schemaのドラフト識別子。
+const SchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
 // getTypeSchema はGoの基本型のJSON Schemaを返す。
 func getTypeSchema(t reflect.Type) map[string]any {
 	switch t.Kind() {
@@ -0,0 +1,157 @@
+package jsonschema
+
+import (
+	"reflect"
+	"sync"
+)
+
+// discriminatorProperty はRegisterUnionで生成されるoneOfスキーマに自動的に
+// 追加される判別用プロパティの名前。
+const discriminatorProperty = "type"
+
+// unionVariant はユニオンの1バリアントを表す。nameはdiscriminatorProperty
+// に設定されるconst値で、既定では型名をそのまま使う。
+type unionVariant struct {
+	typ  reflect.Type
+	name string
+}
+
+var (
+	unionRegistryMu sync.RWMutex
+	unionRegistry   = map[reflect.Type][]unionVariant{}
+
+	namedTypesMu sync.RWMutex
+	namedTypes   = map[string]reflect.Type{}
+)
+
+// RegisterUnion はifaceが指すインターフェース型に対し、variantsをoneOfの
+// 候補として登録する。ifaceは(*Shape)(nil)のようにインターフェースへの型付き
+// nilポインタで渡す。
+//
+// 登録した構造体の型名は、jsonschema:"oneOf=TypeA|TypeB"タグから型を
+// 解決するためにも使われる。
+func RegisterUnion(iface any, variants ...any) {
+	ifaceType := reflect.TypeOf(iface)
+	if ifaceType == nil || ifaceType.Kind() != reflect.Ptr || ifaceType.Elem().Kind() != reflect.Interface {
+		panic("jsonschema: RegisterUnion requires a typed nil pointer to an interface, e.g. (*Shape)(nil)")
+	}
+	ifaceElem := ifaceType.Elem()
+
+	list := make([]unionVariant, 0, len(variants))
+	for _, v := range variants {
+		vt := reflect.TypeOf(v)
+		if vt.Kind() == reflect.Ptr {
+			vt = vt.Elem()
+		}
+		list = append(list, unionVariant{typ: vt, name: vt.Name()})
+
+		// 同じ非修飾名で別の型が既に登録されている場合、defs.goのnameFor/
+		// qualifiedTypeNameと同じ方針でパッケージ名により衝突を解消する
+		// （先に登録された型は非修飾名のまま、後から登録された型は
+		// "pkg.Name"の修飾名でしか引けなくなる）。
+		namedTypesMu.Lock()
+		if existing, ok := namedTypes[vt.Name()]; !ok || existing == vt {
+			namedTypes[vt.Name()] = vt
+		} else {
+			namedTypes[qualifiedTypeName(vt)] = vt
+		}
+		namedTypesMu.Unlock()
+	}
+
+	unionRegistryMu.Lock()
+	unionRegistry[ifaceElem] = list
+	unionRegistryMu.Unlock()
+}
+
+// generateInterfaceSchema はインターフェース型tに対するスキーマを生成する。
+// RegisterUnionで登録されていればoneOf/discriminatorを、されていなければ
+// 従来通り汎用的なobjectスキーマを返す。
+func (c *genContext) generateInterfaceSchema(t reflect.Type) map[string]any {
+	unionRegistryMu.RLock()
+	variants, ok := unionRegistry[t]
+	unionRegistryMu.RUnlock()
+	if !ok {
+		return map[string]any{PropType: TypeObject}
+	}
+	return c.generateUnionSchema(variants)
+}
+
+// generateTaggedUnionSchema はjsonschema:"oneOf=TypeA|TypeB"タグで指定された
+// 型名からoneOfスキーマを生成する。型名がnamedTypesに登録されていない場合は
+// 無視される。
+func (c *genContext) generateTaggedUnionSchema(names []string) map[string]any {
+	variants := make([]unionVariant, 0, len(names))
+	for _, name := range names {
+		namedTypesMu.RLock()
+		t, ok := namedTypes[name]
+		namedTypesMu.RUnlock()
+		if !ok {
+			continue
+		}
+		variants = append(variants, unionVariant{typ: t, name: name})
+	}
+
+	if len(variants) == 0 {
+		return map[string]any{PropType: TypeObject}
+	}
+	return c.generateUnionSchema(variants)
+}
+
+// generateUnionSchema はvariantsそれぞれを"$defs"に切り出し、discriminator
+// 付きのoneOfスキーマを組み立てる。
+func (c *genContext) generateUnionSchema(variants []unionVariant) map[string]any {
+	oneOf := make([]any, 0, len(variants))
+	mapping := make(map[string]any, len(variants))
+
+	for _, variant := range variants {
+		ref, name := c.generateVariantRef(variant)
+		oneOf = append(oneOf, ref)
+		mapping[name] = ref[PropRef]
+	}
+
+	return map[string]any{
+		PropOneOf: oneOf,
+		PropDiscriminator: map[string]any{
+			"propertyName": discriminatorProperty,
+			"mapping":      mapping,
+		},
+	}
+}
+
+// generateVariantRef はvariantの構造体スキーマを"$defs"に登録し（判別用
+// プロパティを注入したうえで）、それを指す"$ref"を返す。
+func (c *genContext) generateVariantRef(variant unionVariant) (ref map[string]any, name string) {
+	name = c.nameFor(variant.typ)
+	ref = map[string]any{PropRef: "#/" + PropDefs + "/" + name}
+
+	if _, exists := c.defs[name]; exists {
+		return ref, name
+	}
+
+	// プレースホルダーを先に登録し、バリアント自身が循環参照する場合の
+	// 無限再帰を防ぐ。
+	c.defs[name] = map[string]any{}
+	schema := c.generateStructSchema(variant.typ)
+	injectDiscriminator(schema, variant.name)
+	c.defs[name] = schema
+
+	return ref, name
+}
+
+// injectDiscriminator はschemaのpropertiesとrequiredに判別用プロパティを
+// 追加する。
+func injectDiscriminator(schema map[string]any, variantName string) {
+	props, ok := schema[PropProperties].(map[string]any)
+	if !ok {
+		props = map[string]any{}
+		schema[PropProperties] = props
+	}
+	props[discriminatorProperty] = map[string]any{
+		PropType:  TypeString,
+		PropConst: variantName,
+	}
+
+	required, _ := schema[PropRequired].([]string)
+	required = append(required, discriminatorProperty)
+	schema[PropRequired] = required
+}
@@ -0,0 +1,213 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+type shapeUnionCircle struct {
+	Radius float64 `json:"radius"`
+}
+
+type shapeUnionSquare struct {
+	Side float64 `json:"side"`
+}
+
+type shapeUnionShape interface {
+	isShapeUnionShape()
+}
+
+func (shapeUnionCircle) isShapeUnionShape() {}
+func (shapeUnionSquare) isShapeUnionShape() {}
+
+// RegisterUnionで登録したインターフェース型のフィールドがoneOf/discriminator
+// 付きのスキーマになることを確認する。
+func TestGenerateRegisteredUnion(t *testing.T) {
+	RegisterUnion((*shapeUnionShape)(nil), shapeUnionCircle{}, shapeUnionSquare{})
+
+	type Drawing struct {
+		Shape shapeUnionShape `json:"shape"`
+	}
+
+	schema, err := Generate(Drawing{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	props := schema[PropProperties].(map[string]any)
+	shape := props["shape"].(map[string]any)
+
+	oneOf, ok := shape[PropOneOf].([]any)
+	if !ok || len(oneOf) != 2 {
+		t.Fatalf("shape[%q] = %v, want 2 branches", PropOneOf, shape[PropOneOf])
+	}
+
+	discriminator, ok := shape[PropDiscriminator].(map[string]any)
+	if !ok || discriminator["propertyName"] != "type" {
+		t.Fatalf("shape[%q] = %v, want propertyName \"type\"", PropDiscriminator, shape[PropDiscriminator])
+	}
+	mapping, ok := discriminator["mapping"].(map[string]any)
+	if !ok || mapping["shapeUnionCircle"] != "#/$defs/shapeUnionCircle" {
+		t.Fatalf("discriminator mapping = %v", discriminator["mapping"])
+	}
+
+	defs := schema[PropDefs].(map[string]any)
+	circleDef := defs["shapeUnionCircle"].(map[string]any)
+	circleProps := circleDef[PropProperties].(map[string]any)
+	typeField := circleProps["type"].(map[string]any)
+	if typeField[PropConst] != "shapeUnionCircle" {
+		t.Errorf("circle type const = %v, want shapeUnionCircle", typeField[PropConst])
+	}
+	required := circleDef[PropRequired].([]string)
+	found := false
+	for _, r := range required {
+		if r == "type" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("circle required = %v, want it to include \"type\"", required)
+	}
+}
+
+type taggedUnionTriangle struct {
+	Base float64 `json:"base"`
+}
+
+// jsonschema:"oneOf=..."タグによる匿名ユニオンの指定も、事前にRegisterUnionで
+// 型名が登録されていればoneOfスキーマになることを確認する。
+func TestGenerateTaggedUnion(t *testing.T) {
+	RegisterUnion((*shapeUnionShape)(nil), taggedUnionTriangle{})
+
+	type Drawing struct {
+		Shape any `json:"shape" jsonschema:"oneOf=taggedUnionTriangle|shapeUnionCircle"`
+	}
+
+	schema, err := Generate(Drawing{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	props := schema[PropProperties].(map[string]any)
+	shape := props["shape"].(map[string]any)
+	oneOf, ok := shape[PropOneOf].([]any)
+	if !ok || len(oneOf) != 2 {
+		t.Fatalf("shape[%q] = %v, want 2 branches", PropOneOf, shape[PropOneOf])
+	}
+}
+
+type selfRefUnionNode struct {
+	Label string            `json:"label"`
+	Next  selfRefUnionShape `json:"next,omitempty"`
+}
+
+type selfRefUnionShape interface {
+	isSelfRefUnionShape()
+}
+
+func (selfRefUnionNode) isSelfRefUnionShape() {}
+
+// interfaceフィールド経由でしか到達できない自己参照バリアントでも、
+// 無限再帰せずに$defsに切り出され、自身への参照が$refになることを確認する。
+func TestGenerateRegisteredUnionSelfReferentialVariant(t *testing.T) {
+	RegisterUnion((*selfRefUnionShape)(nil), selfRefUnionNode{})
+
+	type Drawing struct {
+		Shape selfRefUnionShape `json:"shape"`
+	}
+
+	schema, err := Generate(Drawing{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	defs, ok := schema[PropDefs].(map[string]any)
+	if !ok {
+		t.Fatalf("schema[%q] = %v, want a map", PropDefs, schema[PropDefs])
+	}
+	nodeDef, ok := defs["selfRefUnionNode"].(map[string]any)
+	if !ok {
+		t.Fatalf("defs = %v, want an entry for selfRefUnionNode", defs)
+	}
+	props := nodeDef[PropProperties].(map[string]any)
+	next, ok := props["next"].(map[string]any)
+	if !ok {
+		t.Fatalf("selfRefUnionNode props = %v, want a next property", props)
+	}
+	oneOf, ok := next[PropOneOf].([]any)
+	if !ok || len(oneOf) != 1 {
+		t.Fatalf("next[%q] = %v, want 1 branch referencing itself", PropOneOf, next[PropOneOf])
+	}
+	branch, ok := oneOf[0].(map[string]any)
+	if !ok || branch[PropRef] != "#/$defs/selfRefUnionNode" {
+		t.Errorf("next oneOf[0] = %v, want a $ref back to selfRefUnionNode", oneOf[0])
+	}
+}
+
+type collidingNameUnionShape interface{ isCollidingNameUnionShape() }
+
+func (collidingNameVariant) isCollidingNameUnionShape() {}
+
+type collidingNameVariant struct {
+	X int `json:"x"`
+}
+
+// 別パッケージの型が同じ非修飾名(例えば"collidingNameVariant")で既に
+// namedTypesに登録されていた場合を再現し、RegisterUnionが後発の型で
+// 先発のエントリを上書きしないことを確認する。先発の型は引き続き
+// 非修飾名で、後発の型は修飾名でしか引けなくなる。
+func TestRegisterUnionNameCollisionDoesNotClobber(t *testing.T) {
+	var otherPkgPlaceholder struct{}
+	otherPkgType := reflect.TypeOf(otherPkgPlaceholder)
+
+	namedTypesMu.Lock()
+	namedTypes["collidingNameVariant"] = otherPkgType
+	namedTypesMu.Unlock()
+
+	RegisterUnion((*collidingNameUnionShape)(nil), collidingNameVariant{})
+
+	namedTypesMu.RLock()
+	bareEntry := namedTypes["collidingNameVariant"]
+	qualifiedEntry := namedTypes[qualifiedTypeName(reflect.TypeOf(collidingNameVariant{}))]
+	namedTypesMu.RUnlock()
+
+	if bareEntry != otherPkgType {
+		t.Errorf("namedTypes[%q] = %v, want the first-registered type to survive", "collidingNameVariant", bareEntry)
+	}
+	if qualifiedEntry != reflect.TypeOf(collidingNameVariant{}) {
+		t.Errorf("namedTypes[%q] = %v, want collidingNameVariant reachable under its qualified name", qualifiedTypeName(reflect.TypeOf(collidingNameVariant{})), qualifiedEntry)
+	}
+}
+
+// Validatorは生成されたoneOf/discriminatorスキーマに対して、ちょうど1つの
+// バリアントにのみ一致する値を要求する。
+func TestValidatorValidatesUnion(t *testing.T) {
+	RegisterUnion((*shapeUnionShape)(nil), shapeUnionCircle{}, shapeUnionSquare{})
+
+	type Drawing struct {
+		Shape shapeUnionShape `json:"shape"`
+	}
+
+	schema, err := Generate(Drawing{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	valid := map[string]any{
+		"shape": map[string]any{"type": "shapeUnionCircle", "radius": float64(1)},
+	}
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	invalid := map[string]any{
+		"shape": map[string]any{"type": "shapeUnionHexagon", "radius": float64(1)},
+	}
+	if err := validator.Validate(invalid); err == nil {
+		t.Error("Validate() error = nil, want an error for unknown discriminator value")
+	}
+}
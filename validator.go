@@ -0,0 +1,531 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// FormatChecker は"format"キーワードの値を検証するインターフェース。
+// RegisterFormatでカスタムフォーマットを登録する際に実装する。
+type FormatChecker interface {
+	IsFormat(value string) bool
+}
+
+// FormatCheckerFunc は関数をFormatCheckerとして使えるようにするアダプタ。
+type FormatCheckerFunc func(value string) bool
+
+// IsFormat はfをそのまま呼び出す。
+func (f FormatCheckerFunc) IsFormat(value string) bool {
+	return f(value)
+}
+
+var (
+	formatCheckersMu sync.RWMutex
+	formatCheckers   = map[string]FormatChecker{
+		"date-time": FormatCheckerFunc(isDateTime),
+		"date":      FormatCheckerFunc(isDate),
+		"time":      FormatCheckerFunc(isTime),
+		"duration":  FormatCheckerFunc(isDuration),
+		"email":     FormatCheckerFunc(isEmail),
+		"hostname":  FormatCheckerFunc(isHostname),
+		"ipv4":      FormatCheckerFunc(isIPv4),
+		"ipv6":      FormatCheckerFunc(isIPv6),
+		"uuid":      FormatCheckerFunc(isUUID),
+	}
+)
+
+// RegisterFormat はnameという名前のカスタムフォーマットチェッカーを登録する。
+// 既存の組み込みフォーマット（email, uuidなど）も上書きできる。
+func RegisterFormat(name string, fn FormatChecker) {
+	formatCheckersMu.Lock()
+	defer formatCheckersMu.Unlock()
+	formatCheckers[name] = fn
+}
+
+func checkFormat(name, value string) bool {
+	formatCheckersMu.RLock()
+	checker, ok := formatCheckers[name]
+	formatCheckersMu.RUnlock()
+	if !ok {
+		// 未知のフォーマットはJSON Schemaの仕様上は注釈に過ぎないため検証しない。
+		return true
+	}
+	return checker.IsFormat(value)
+}
+
+var (
+	dateTimeRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+	dateRe     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	timeRe     = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?$`)
+	durationRe = regexp.MustCompile(`^P(?:\d+Y)?(?:\d+M)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:\.\d+)?S)?)?$`)
+	emailRe    = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+	hostnameRe = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	uuidRe     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+func isDateTime(v string) bool { return dateTimeRe.MatchString(v) }
+func isDate(v string) bool     { return dateRe.MatchString(v) }
+func isTime(v string) bool     { return timeRe.MatchString(v) }
+func isDuration(v string) bool { return v != "P" && durationRe.MatchString(v) }
+func isEmail(v string) bool    { return emailRe.MatchString(v) }
+func isHostname(v string) bool { return len(v) <= 253 && hostnameRe.MatchString(v) }
+func isUUID(v string) bool     { return uuidRe.MatchString(v) }
+
+func isIPv4(v string) bool {
+	ip := net.ParseIP(v)
+	return ip != nil && ip.To4() != nil && strings.Count(v, ":") == 0
+}
+
+func isIPv6(v string) bool {
+	ip := net.ParseIP(v)
+	return ip != nil && ip.To4() == nil
+}
+
+// ValidationError はスキーマに違反した値1件を表す。PathはRFC 6901のJSON
+// Pointer形式で、違反があった場所を示す（ルートは空文字列）。
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors はValidateで見つかった違反をすべて保持する。
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	parts := make([]string, len(es))
+	for i, e := range es {
+		parts[i] = e.Error()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Validator はCompileで作成され、JSON Schemaを保持し、Validateで値を検証する。
+// patternの正規表現は初回使用時にコンパイルしてキャッシュする。
+type Validator struct {
+	schema map[string]any
+	defs   map[string]any
+
+	patternMu    sync.Mutex
+	patternCache map[string]*regexp.Regexp
+}
+
+// Compile はschemaを保持するValidatorを作成する。schemaがnilの場合のみエラーを
+// 返し、schema自体の妥当性は検査しない。
+func Compile(schema map[string]any) (*Validator, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("jsonschema: cannot compile nil schema")
+	}
+
+	v := &Validator{
+		schema:       schema,
+		patternCache: map[string]*regexp.Regexp{},
+	}
+	if defs, ok := schema[PropDefs].(map[string]any); ok {
+		v.defs = defs
+	}
+	return v, nil
+}
+
+// Validate はvalueをコンパイル済みのスキーマに照らして検証する。違反がなければ
+// nilを返し、1件以上あればValidationErrorsを返す。
+func (v *Validator) Validate(value any) error {
+	data, err := toGeneric(value)
+	if err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+	v.validateNode(v.schema, data, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// toGeneric はvalueをJSONデコード後の表現（map[string]any, []any, string,
+// float64, bool, nil）に変換する。string/float64/bool/nilはそのまま使い、
+// mapやスライスは要素も変換するため常にJSONを経由する。
+func toGeneric(value any) (any, error) {
+	switch value.(type) {
+	case string, float64, bool, nil:
+		return value, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("jsonschema: cannot marshal value for validation: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, fmt.Errorf("jsonschema: cannot unmarshal value for validation: %w", err)
+	}
+	return generic, nil
+}
+
+// resolve はschemaが"$ref"を持つ場合にv.defsから参照先を解決する。
+func (v *Validator) resolve(schema map[string]any) (map[string]any, error) {
+	ref, ok := schema[PropRef].(string)
+	if !ok {
+		return schema, nil
+	}
+
+	const prefix = "#/$defs/"
+	if !strings.HasPrefix(ref, prefix) {
+		return nil, fmt.Errorf("jsonschema: unsupported $ref %q", ref)
+	}
+
+	name := strings.TrimPrefix(ref, prefix)
+	def, ok := v.defs[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("jsonschema: $ref %q not found in $defs", ref)
+	}
+	return def, nil
+}
+
+func (v *Validator) validateNode(schema map[string]any, data any, path string, errs *ValidationErrors) {
+	resolved, err := v.resolve(schema)
+	if err != nil {
+		v.addError(errs, path, err.Error())
+		return
+	}
+	schema = resolved
+
+	if oneOf, ok := schema[PropOneOf].([]any); ok {
+		v.validateOneOf(oneOf, data, path, errs)
+		return
+	}
+
+	if t, ok := schema[PropType].(string); ok {
+		if !matchesType(t, data) {
+			v.addError(errs, path, fmt.Sprintf("expected type %q, got %s", t, jsonTypeOf(data)))
+			return
+		}
+	}
+
+	if constVal, ok := schema[PropConst]; ok && !valuesEqual(constVal, data) {
+		v.addError(errs, path, fmt.Sprintf("must equal const %v", constVal))
+	}
+
+	if enum, ok := schema[PropEnum].([]any); ok && !enumContains(enum, data) {
+		v.addError(errs, path, fmt.Sprintf("must be one of %v", enum))
+	}
+
+	switch d := data.(type) {
+	case map[string]any:
+		v.validateObject(schema, d, path, errs)
+	case []any:
+		v.validateArray(schema, d, path, errs)
+	case float64:
+		v.validateNumber(schema, d, path, errs)
+	case string:
+		v.validateString(schema, d, path, errs)
+	}
+}
+
+// validateOneOf はdataがbranchesのうちちょうど1つにだけ一致することを要求する。
+func (v *Validator) validateOneOf(branches []any, data any, path string, errs *ValidationErrors) {
+	matches := 0
+	for _, b := range branches {
+		branchSchema, ok := b.(map[string]any)
+		if !ok {
+			continue
+		}
+		var branchErrs ValidationErrors
+		v.validateNode(branchSchema, data, path, &branchErrs)
+		if len(branchErrs) == 0 {
+			matches++
+		}
+	}
+
+	switch {
+	case matches == 1:
+		return
+	case matches == 0:
+		v.addError(errs, path, fmt.Sprintf("value does not match any of the %d oneOf schemas", len(branches)))
+	default:
+		v.addError(errs, path, fmt.Sprintf("value matches %d oneOf schemas, want exactly 1", matches))
+	}
+}
+
+func (v *Validator) validateObject(schema map[string]any, data map[string]any, path string, errs *ValidationErrors) {
+	for _, name := range requiredNames(schema[PropRequired]) {
+		if _, present := data[name]; !present {
+			v.addError(errs, path, fmt.Sprintf("missing required property %q", name))
+		}
+	}
+
+	props, _ := schema[PropProperties].(map[string]any)
+	for name, value := range data {
+		propSchema, known := props[name]
+		if !known {
+			v.validateAdditionalProperty(schema, name, value, path, errs)
+			continue
+		}
+		propMap, ok := propSchema.(map[string]any)
+		if !ok {
+			continue
+		}
+		v.validateNode(propMap, value, joinPointer(path, name), errs)
+	}
+}
+
+func (v *Validator) validateAdditionalProperty(schema map[string]any, name string, value any, path string, errs *ValidationErrors) {
+	ap, ok := schema[PropAdditionalProperties]
+	if !ok {
+		return
+	}
+	switch apv := ap.(type) {
+	case bool:
+		if !apv {
+			v.addError(errs, joinPointer(path, name), "additional property not allowed")
+		}
+	case map[string]any:
+		v.validateNode(apv, value, joinPointer(path, name), errs)
+	}
+}
+
+func (v *Validator) validateArray(schema map[string]any, data []any, path string, errs *ValidationErrors) {
+	if minItems, ok := toInt(schema[PropMinItems]); ok && len(data) < minItems {
+		v.addError(errs, path, fmt.Sprintf("expected at least %d items, got %d", minItems, len(data)))
+	}
+	if maxItems, ok := toInt(schema[PropMaxItems]); ok && len(data) > maxItems {
+		v.addError(errs, path, fmt.Sprintf("expected at most %d items, got %d", maxItems, len(data)))
+	}
+	if unique, ok := schema[PropUniqueItems].(bool); ok && unique && hasDuplicates(data) {
+		v.addError(errs, path, "items must be unique")
+	}
+
+	itemSchema, ok := schema[PropItems].(map[string]any)
+	if !ok {
+		return
+	}
+	for i, item := range data {
+		v.validateNode(itemSchema, item, fmt.Sprintf("%s/%d", path, i), errs)
+	}
+}
+
+func (v *Validator) validateNumber(schema map[string]any, n float64, path string, errs *ValidationErrors) {
+	if min, ok := toFloat(schema[PropMinimum]); ok && n < min {
+		v.addError(errs, path, fmt.Sprintf("must be >= %v", min))
+	}
+	if max, ok := toFloat(schema[PropMaximum]); ok && n > max {
+		v.addError(errs, path, fmt.Sprintf("must be <= %v", max))
+	}
+	if emin, ok := toFloat(schema[PropExclusiveMinimum]); ok && n <= emin {
+		v.addError(errs, path, fmt.Sprintf("must be > %v", emin))
+	}
+	if emax, ok := toFloat(schema[PropExclusiveMaximum]); ok && n >= emax {
+		v.addError(errs, path, fmt.Sprintf("must be < %v", emax))
+	}
+	if mult, ok := toFloat(schema[PropMultipleOf]); ok && mult != 0 && !isMultipleOf(n, mult) {
+		v.addError(errs, path, fmt.Sprintf("must be a multiple of %v", mult))
+	}
+}
+
+func (v *Validator) validateString(schema map[string]any, s string, path string, errs *ValidationErrors) {
+	length := len([]rune(s))
+	if minLength, ok := toInt(schema[PropMinLength]); ok && length < minLength {
+		v.addError(errs, path, fmt.Sprintf("length must be >= %d, got %d", minLength, length))
+	}
+	if maxLength, ok := toInt(schema[PropMaxLength]); ok && length > maxLength {
+		v.addError(errs, path, fmt.Sprintf("length must be <= %d, got %d", maxLength, length))
+	}
+	if pattern, ok := schema[PropPattern].(string); ok {
+		re, err := v.compilePattern(pattern)
+		if err != nil {
+			v.addError(errs, path, fmt.Sprintf("invalid pattern %q: %v", pattern, err))
+		} else if !re.MatchString(s) {
+			v.addError(errs, path, fmt.Sprintf("does not match pattern %q", pattern))
+		}
+	}
+	if format, ok := schema[PropFormat].(string); ok {
+		if !checkFormat(format, s) {
+			v.addError(errs, path, fmt.Sprintf("does not match format %q", format))
+		}
+	}
+}
+
+func (v *Validator) compilePattern(pattern string) (*regexp.Regexp, error) {
+	v.patternMu.Lock()
+	defer v.patternMu.Unlock()
+
+	if re, ok := v.patternCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.patternCache[pattern] = re
+	return re, nil
+}
+
+func (v *Validator) addError(errs *ValidationErrors, path, message string) {
+	*errs = append(*errs, &ValidationError{Path: path, Message: message})
+}
+
+func joinPointer(base, key string) string {
+	key = strings.ReplaceAll(key, "~", "~0")
+	key = strings.ReplaceAll(key, "/", "~1")
+	return base + "/" + key
+}
+
+func requiredNames(raw any) []string {
+	switch r := raw.(type) {
+	case []string:
+		return r
+	case []any:
+		names := make([]string, 0, len(r))
+		for _, n := range r {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+func matchesType(t string, data any) bool {
+	switch t {
+	case TypeString:
+		_, ok := data.(string)
+		return ok
+	case TypeInteger:
+		n, ok := data.(float64)
+		return ok && n == math.Trunc(n)
+	case TypeNumber:
+		_, ok := data.(float64)
+		return ok
+	case TypeBoolean:
+		_, ok := data.(bool)
+		return ok
+	case TypeObject:
+		_, ok := data.(map[string]any)
+		return ok
+	case TypeArray:
+		_, ok := data.([]any)
+		return ok
+	default:
+		return true
+	}
+}
+
+func jsonTypeOf(data any) string {
+	switch data.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return TypeBoolean
+	case float64:
+		return TypeNumber
+	case string:
+		return TypeString
+	case []any:
+		return TypeArray
+	case map[string]any:
+		return TypeObject
+	default:
+		return fmt.Sprintf("%T", data)
+	}
+}
+
+func toFloat(raw any) (float64, bool) {
+	switch n := raw.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func toInt(raw any) (int, bool) {
+	switch n := raw.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+func valuesEqual(a, b any) bool {
+	if af, ok := numericValue(a); ok {
+		bf, ok := numericValue(b)
+		return ok && af == bf
+	}
+	return a == b
+}
+
+func numericValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func enumContains(enum []any, data any) bool {
+	for _, e := range enum {
+		if valuesEqual(e, data) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDuplicates(items []any) bool {
+	b, err := json.Marshal(items)
+	if err != nil {
+		return false
+	}
+	var normalized []any
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return false
+	}
+
+	seen := make(map[string]bool, len(normalized))
+	for _, item := range normalized {
+		key, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		if seen[string(key)] {
+			return true
+		}
+		seen[string(key)] = true
+	}
+	return false
+}
+
+func isMultipleOf(n, mult float64) bool {
+	if mult == 0 {
+		return true
+	}
+	quotient := n / mult
+	return math.Abs(quotient-math.Round(quotient)) < 1e-9
+}
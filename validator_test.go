@@ -0,0 +1,247 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatorValidate(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" validate:"required"`
+		City   string `json:"city" validate:"required"`
+	}
+
+	type User struct {
+		ID      int      `json:"id" validate:"required,minimum=1"`
+		Name    string   `json:"name" validate:"required"`
+		Email   string   `json:"email" validate:"format=email"`
+		Tags    []string `json:"tags" validate:"minItems=1,maxItems=3"`
+		Address Address  `json:"address"`
+	}
+
+	schema, err := Generate(User{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   map[string]any
+		wantErr bool
+	}{
+		{
+			name: "正常系: すべての制約を満たす",
+			input: map[string]any{
+				"id":    float64(1),
+				"name":  "Alice",
+				"email": "alice@example.com",
+				"tags":  []any{"a"},
+				"address": map[string]any{
+					"street": "Main St",
+					"city":   "Springfield",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 必須プロパティの欠落",
+			input: map[string]any{
+				"name": "Alice",
+				"address": map[string]any{
+					"street": "Main St",
+					"city":   "Springfield",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系: minimum違反",
+			input: map[string]any{
+				"id":    float64(0),
+				"name":  "Alice",
+				"email": "alice@example.com",
+				"tags":  []any{"a"},
+				"address": map[string]any{
+					"street": "Main St",
+					"city":   "Springfield",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系: 型不一致",
+			input: map[string]any{
+				"id":    "not-an-int",
+				"name":  "Alice",
+				"email": "alice@example.com",
+				"tags":  []any{"a"},
+				"address": map[string]any{
+					"street": "Main St",
+					"city":   "Springfield",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系: フォーマット違反",
+			input: map[string]any{
+				"id":    float64(1),
+				"name":  "Alice",
+				"email": "not-an-email",
+				"tags":  []any{"a"},
+				"address": map[string]any{
+					"street": "Main St",
+					"city":   "Springfield",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系: ネストした必須プロパティの欠落",
+			input: map[string]any{
+				"id":    float64(1),
+				"name":  "Alice",
+				"email": "alice@example.com",
+				"tags":  []any{"a"},
+				"address": map[string]any{
+					"city": "Springfield",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// ValidateはGoのネイティブ値も受け付け、JSON相当の表現に変換して検証する。
+func TestValidatorValidateNativeStruct(t *testing.T) {
+	type Item struct {
+		Name  string `json:"name" validate:"required"`
+		Price int    `json:"price" validate:"minimum=0"`
+	}
+
+	schema, err := Generate(Item{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	if err := validator.Validate(Item{Name: "Widget", Price: 10}); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := validator.Validate(Item{Name: "Widget", Price: -1}); err == nil {
+		t.Errorf("Validate() error = nil, want an error for negative price")
+	}
+}
+
+// 違反したプロパティのパスがJSON Pointer形式で報告されることを確認する。
+func TestValidatorErrorPaths(t *testing.T) {
+	type Inner struct {
+		Count int `json:"count" validate:"minimum=1"`
+	}
+	type Outer struct {
+		Inner Inner `json:"inner"`
+	}
+
+	schema, err := Generate(Outer{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	err = validator.Validate(map[string]any{
+		"inner": map[string]any{"count": float64(0)},
+	})
+	if err == nil {
+		t.Fatal("Validate() error = nil, want an error")
+	}
+	verrs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("error type = %T, want ValidationErrors", err)
+	}
+	if len(verrs) != 1 || verrs[0].Path != "/inner/count" {
+		t.Errorf("errors = %v, want a single error at /inner/count", verrs)
+	}
+}
+
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("even-digits", FormatCheckerFunc(func(v string) bool {
+		return len(v)%2 == 0
+	}))
+
+	schema := map[string]any{
+		PropType: TypeObject,
+		PropProperties: map[string]any{
+			"code": map[string]any{PropType: TypeString, PropFormat: "even-digits"},
+		},
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	if err := validator.Validate(map[string]any{"code": "1234"}); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := validator.Validate(map[string]any{"code": "123"}); err == nil {
+		t.Error("Validate() error = nil, want an error for odd-length code")
+	}
+}
+
+func TestValidatorResolvesRefs(t *testing.T) {
+	type Node struct {
+		Name     string  `json:"name"`
+		Children []*Node `json:"children"`
+	}
+
+	schema, err := Generate(Node{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	validator, err := Compile(schema)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	valid := map[string]any{
+		"name": "root",
+		"children": []any{
+			map[string]any{"name": "child", "children": []any{}},
+		},
+	}
+	if err := validator.Validate(valid); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	invalid := map[string]any{
+		"name": "root",
+		"children": []any{
+			map[string]any{"children": []any{}},
+		},
+	}
+	err = validator.Validate(invalid)
+	if err == nil {
+		t.Fatal("Validate() error = nil, want an error for missing nested name")
+	}
+	if !strings.Contains(err.Error(), "/children/0") {
+		t.Errorf("error = %v, want a path mentioning /children/0", err)
+	}
+}